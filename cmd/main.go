@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/fbufler/mangadex/pkg/mangadex"
+	"github.com/fbufler/mangadex/pkg/packer"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +33,20 @@ var getCmd = &cobra.Command{
 		language, _ := cmd.Flags().GetString("language")
 		retries, _ := cmd.Flags().GetInt("retries")
 		volume, _ := cmd.Flags().GetString("volume")
+		parallelChapters, _ := cmd.Flags().GetInt("parallel-chapters")
+		parallelPages, _ := cmd.Flags().GetInt("parallel-pages")
+		cacheEnabled, _ := cmd.Flags().GetBool("cache")
+		cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+		this, _ := cmd.Flags().GetBool("this")
+		chapters, _ := cmd.Flags().GetString("chapters")
+		format, _ := cmd.Flags().GetString("format")
+		saveCovers, _ := cmd.Flags().GetBool("save-covers")
+
+		target, err := mangadex.ParseTarget(manga)
+		if err != nil {
+			slog.Error("Failed to parse manga target", "error", err)
+			return
+		}
 
 		mangaLanguage := mangadex.MangaLanguageEN
 		switch language {
@@ -50,19 +65,52 @@ var getCmd = &cobra.Command{
 			return
 		}
 
+		outputFormat := packer.Format(format)
+		switch outputFormat {
+		case packer.FormatCBZ, packer.FormatCBR, packer.FormatPDF, packer.FormatEPUB:
+		default:
+			slog.Error("Unsupported output format", "format", format)
+			return
+		}
+
+		if target.Kind == mangadex.TargetKindChapter && !this {
+			slog.Error("Chapter URL provided without --this; pass --this to download just that chapter, or provide a title URL/ID to download the whole manga")
+			return
+		}
+
 		cfg := &mangadex.Config{
-			APIUrl:        apiUrl,
-			Timeout:       time.Second * 10,
-			MangaID:       manga,
-			MangaLanguage: mangaLanguage,
-			Retries:       retries,
-			Output:        output,
-			Volume:        volume,
-			Name:          name,
-			Logger:        slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			APIUrl:           apiUrl,
+			Timeout:          time.Second * 10,
+			MangaLanguage:    mangaLanguage,
+			Retries:          retries,
+			Output:           output,
+			Volume:           volume,
+			Chapters:         chapters,
+			Name:             name,
+			Logger:           slog.New(slog.NewTextHandler(os.Stdout, nil)),
+			ParallelChapters: parallelChapters,
+			ParallelPages:    parallelPages,
+			CacheEnabled:     cacheEnabled,
+			CacheTTL:         cacheTTL,
+			OutputFormat:     outputFormat,
+			SaveCovers:       saveCovers,
+		}
+		switch target.Kind {
+		case mangadex.TargetKindChapter:
+			cfg.ChapterID = target.UUID
+		case mangadex.TargetKindTitle:
+			cfg.MangaID = target.UUID
 		}
 
 		mangadexClient := mangadex.New(cfg)
+		if cfg.ChapterID != "" {
+			if err := mangadexClient.DownloadChapter(cfg.ChapterID); err != nil {
+				slog.Error("Failed to download chapter", "error", err)
+				return
+			}
+			slog.Info("Chapter downloaded successfully", "output", output)
+			return
+		}
 		if err := mangadexClient.DownloadManga(); err != nil {
 			slog.Error("Failed to download manga", "error", err)
 			return
@@ -76,12 +124,20 @@ func init() {
 	rootCmd.AddCommand(getCmd)
 
 	// Add flags to the get command
-	getCmd.Flags().StringP("manga", "m", "", "The Manga ID to download")
+	getCmd.Flags().StringP("manga", "m", "", "The Manga ID, or a mangadex.org title/chapter URL, to download")
 	getCmd.Flags().StringP("output", "o", "", "The output directory to save the manga")
 	getCmd.Flags().StringP("name", "n", "", "The name of the manga to download")
 	getCmd.Flags().StringP("language", "l", "en", "The language of the manga to download, supported languages: en, de")
 	getCmd.Flags().IntP("retries", "r", 3, "Number of retries for failed downloads")
 	getCmd.Flags().StringP("volume", "v", "", "The volume of the manga to download")
+	getCmd.Flags().Int("parallel-chapters", 1, "Number of chapters to download in parallel")
+	getCmd.Flags().Int("parallel-pages", 1, "Number of pages per chapter to download in parallel")
+	getCmd.Flags().Bool("cache", false, "Cache API responses on disk to speed up re-runs")
+	getCmd.Flags().Duration("cache-ttl", 0, "How long cached API responses stay valid (0 = forever)")
+	getCmd.Flags().Bool("this", false, "When --manga is a chapter URL, download only that chapter instead of the whole manga")
+	getCmd.Flags().String("chapters", "", "Only download chapters in this numeric range, e.g. 12-34")
+	getCmd.Flags().String("format", "cbz", "Output archive format: cbz, cbr, pdf, or epub")
+	getCmd.Flags().Bool("save-covers", false, "Keep downloaded cover art under <output>/covers")
 }
 
 func main() {
@@ -0,0 +1,99 @@
+package cbz
+
+import "encoding/xml"
+
+// PageType mirrors the ComicRack ComicInfo.xml Page Type attribute.
+type PageType string
+
+const (
+	// PageTypeFrontCover marks a page as the front cover.
+	PageTypeFrontCover PageType = "FrontCover"
+	// PageTypeStory marks a page as regular story content.
+	PageTypeStory PageType = "Story"
+)
+
+// Page describes a single page entry in ComicInfo.xml.
+type Page struct {
+	Image  int
+	Type   PageType
+	Width  int
+	Height int
+}
+
+// Metadata holds the ComicRack ComicInfo.xml fields the packer package's CBZ
+// and CBR packagers embed at the archive root, so the produced archive is
+// recognized by Komga, Kavita, and ComicRack-compatible readers.
+type Metadata struct {
+	Series      string
+	Volume      string
+	Number      string
+	Title       string
+	Summary     string
+	Writer      string
+	Penciller   string
+	Genre       string
+	LanguageISO string
+	PageCount   int
+	Pages       []Page
+}
+
+type comicInfoXML struct {
+	XMLName     xml.Name       `xml:"ComicInfo"`
+	Series      string         `xml:"Series,omitempty"`
+	Volume      string         `xml:"Volume,omitempty"`
+	Number      string         `xml:"Number,omitempty"`
+	Title       string         `xml:"Title,omitempty"`
+	Summary     string         `xml:"Summary,omitempty"`
+	Writer      string         `xml:"Writer,omitempty"`
+	Penciller   string         `xml:"Penciller,omitempty"`
+	Genre       string         `xml:"Genre,omitempty"`
+	LanguageISO string         `xml:"LanguageISO,omitempty"`
+	PageCount   int            `xml:"PageCount,omitempty"`
+	Pages       *comicPagesXML `xml:"Pages,omitempty"`
+}
+
+type comicPagesXML struct {
+	Page []comicPageXML `xml:"Page"`
+}
+
+type comicPageXML struct {
+	Image       int    `xml:"Image,attr"`
+	Type        string `xml:"Type,attr,omitempty"`
+	ImageWidth  int    `xml:"ImageWidth,attr,omitempty"`
+	ImageHeight int    `xml:"ImageHeight,attr,omitempty"`
+}
+
+// Marshal renders Metadata as a ComicInfo.xml document, including the XML
+// declaration readers expect at the top of the file.
+func (m *Metadata) Marshal() ([]byte, error) {
+	doc := comicInfoXML{
+		Series:      m.Series,
+		Volume:      m.Volume,
+		Number:      m.Number,
+		Title:       m.Title,
+		Summary:     m.Summary,
+		Writer:      m.Writer,
+		Penciller:   m.Penciller,
+		Genre:       m.Genre,
+		LanguageISO: m.LanguageISO,
+		PageCount:   m.PageCount,
+	}
+	if len(m.Pages) > 0 {
+		pages := make([]comicPageXML, len(m.Pages))
+		for i, page := range m.Pages {
+			pages[i] = comicPageXML{
+				Image:       page.Image,
+				Type:        string(page.Type),
+				ImageWidth:  page.Width,
+				ImageHeight: page.Height,
+			}
+		}
+		doc.Pages = &comicPagesXML{Page: pages}
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,172 @@
+package mangadex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// atHomeReportURL is where MD@Home client success/failure reports are
+// submitted, per MangaDex's At-Home client TOS.
+const atHomeReportURL = "https://api.mangadex.network/report"
+
+// imageMaxRetries and imageRetryBaseDelay govern downloadImage's own
+// exponential backoff, kept separate from retryRequest/RETRY_WAIT_TIME since
+// a single page failing shouldn't pay the much longer API-level retry delay.
+const (
+	imageMaxRetries     = 3
+	imageRetryBaseDelay = 500 * time.Millisecond
+)
+
+// downloadImage streams imageURL to outputPath, retrying transient failures
+// with exponential backoff independently of the API-level retry used for
+// JSON endpoints. If the At-Home server reports a content checksum, the
+// downloaded bytes are verified against it before the download is considered
+// successful. When report is true, the download's final outcome (one report
+// covering all attempts) is sent to MangaDex's At-Home report endpoint;
+// a failure to submit that report is logged but never fails the download
+// itself. report must only be set for images actually served from an
+// At-Home mirror (i.e. the baseUrl returned by /at-home/server/{id}) —
+// submitting At-Home telemetry for a regular CDN host like
+// uploads.mangadex.org is a misuse of the reporting protocol.
+func (c *Client) downloadImage(ctx context.Context, imageURL, outputPath string, report bool) error {
+	start := time.Now()
+	var (
+		bytesWritten int64
+		cached       bool
+		success      bool
+		lastErr      error
+	)
+
+	wait := imageRetryBaseDelay
+retryLoop:
+	for attempt := 0; attempt <= imageMaxRetries; attempt++ {
+		if attempt > 0 {
+			c.logger.Warn("Retrying image download", "url", imageURL, "attempt", attempt, "error", lastErr)
+			select {
+			case <-ctx.Done():
+				lastErr = ctx.Err()
+				break retryLoop
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+
+		n, isCached, err := c.streamImage(ctx, imageURL, outputPath)
+		if err == nil {
+			bytesWritten, cached, success = n, isCached, true
+			break retryLoop
+		}
+		lastErr = err
+	}
+
+	if report {
+		c.reportAtHome(imageURL, success, cached, bytesWritten, time.Since(start))
+	}
+
+	if !success {
+		return fmt.Errorf("failed to download image %s: %w", imageURL, lastErr)
+	}
+	return nil
+}
+
+// streamImage issues a single GET for imageURL and copies the response
+// directly to outputPath, verifying the At-Home server's X-Content-SHA256
+// checksum (the mechanism MD@Home actually uses to let clients verify page
+// integrity, since the data/dataSaver filenames returned by /at-home/server
+// carry no checksum of their own) when present. It reports whether the
+// response was served from the At-Home node's own cache via X-Cache.
+func (c *Client) streamImage(ctx context.Context, imageURL, outputPath string) (int64, bool, error) {
+	c.awaitRateLimit()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.logger.Warn("Rate limit reached downloading image, waiting 5 seconds")
+		c.triggerRateLimit(5 * time.Second)
+		return 0, false, fmt.Errorf("rate limited downloading image %s", imageURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("image download failed with status %s", resp.Status)
+	}
+
+	cached := strings.Contains(strings.ToUpper(resp.Header.Get("X-Cache")), "HIT")
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return 0, cached, fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer out.Close()
+
+	hasher := sha256.New()
+	written, err := io.Copy(out, io.TeeReader(resp.Body, hasher))
+	if err != nil {
+		return 0, cached, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	if expected := resp.Header.Get("X-Content-SHA256"); expected != "" {
+		if actual := hex.EncodeToString(hasher.Sum(nil)); !strings.EqualFold(expected, actual) {
+			os.Remove(outputPath)
+			return 0, cached, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", imageURL, expected, actual)
+		}
+	}
+
+	return written, cached, nil
+}
+
+// reportAtHome submits a single download's outcome to MangaDex's At-Home
+// report endpoint, as MD@Home clients are required to do. Reporting failures
+// are logged rather than returned, since a report outage shouldn't turn an
+// otherwise-successful page download into an error.
+func (c *Client) reportAtHome(url string, success, cached bool, bytesWritten int64, duration time.Duration) {
+	body, err := json.Marshal(struct {
+		URL      string `json:"url"`
+		Success  bool   `json:"success"`
+		Cached   bool   `json:"cached"`
+		Bytes    int64  `json:"bytes"`
+		Duration int64  `json:"duration"`
+	}{
+		URL:      url,
+		Success:  success,
+		Cached:   cached,
+		Bytes:    bytesWritten,
+		Duration: duration.Milliseconds(),
+	})
+	if err != nil {
+		c.logger.Warn("Failed to build At-Home report", "error", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, atHomeReportURL, bytes.NewReader(body))
+	if err != nil {
+		c.logger.Warn("Failed to build At-Home report request", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		c.logger.Warn("Failed to submit At-Home report", "url", url, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		c.logger.Warn("At-Home report rejected", "url", url, "status", resp.Status)
+	}
+}
@@ -0,0 +1,72 @@
+package mangadex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// responseCache is an opt-in, on-disk cache for API responses, keyed by a
+// hash of the request URL. It lives under os.UserCacheDir()/mangadex-loader
+// so re-running a download after a partial failure doesn't re-fetch chapter,
+// manga and cover metadata that hasn't changed. Which URLs are cached is
+// decided by cacheableURL; /at-home/server responses are not cached.
+type responseCache struct {
+	dir     string
+	ttl     time.Duration
+	enabled bool
+}
+
+func newResponseCache(enabled bool, ttl time.Duration) (*responseCache, error) {
+	if !enabled {
+		return &responseCache{enabled: false}, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user cache dir: %w", err)
+	}
+	dir := filepath.Join(userCacheDir, "mangadex-loader")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir %s: %w", dir, err)
+	}
+	return &responseCache{dir: dir, ttl: ttl, enabled: true}, nil
+}
+
+func (rc *responseCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:]))
+}
+
+// get returns the cached body for url, if present and not expired.
+func (rc *responseCache) get(url string) ([]byte, bool) {
+	if !rc.enabled {
+		return nil, false
+	}
+	path := rc.path(url)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if rc.ttl > 0 && time.Since(info.ModTime()) > rc.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// put stores body under url's cache key. Failures are non-fatal: a cache
+// write failure should not fail the download it's caching.
+func (rc *responseCache) put(url string, body []byte) {
+	if !rc.enabled {
+		return
+	}
+	if err := os.WriteFile(rc.path(url), body, 0o644); err != nil {
+		return
+	}
+}
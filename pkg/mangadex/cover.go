@@ -0,0 +1,93 @@
+package mangadex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cover describes a single manga cover as returned by /cover, matched to a
+// volume via its Volume attribute.
+type Cover struct {
+	ID       string
+	Volume   string
+	FileName string
+}
+
+// GetMangaCovers fetches every cover for mangaID from /cover?manga={id},
+// paginating until all results are collected.
+func (c *Client) GetMangaCovers(mangaID string) ([]Cover, error) {
+	var covers []Cover
+	limit := 100
+	offset := 0
+
+	for {
+		apiURL := fmt.Sprintf("%s/cover?manga[]=%s&limit=%d&offset=%d", c.Config.APIUrl.String(), mangaID, limit, offset)
+		resp, err := c.makeRequest("GET", apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch covers: %w", err)
+		}
+		defer resp.Body.Close()
+
+		var result struct {
+			Data []struct {
+				ID         string `json:"id"`
+				Attributes struct {
+					Volume   string `json:"volume"`
+					FileName string `json:"fileName"`
+				} `json:"attributes"`
+			} `json:"data"`
+			Total int `json:"total"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode cover response: %w", err)
+		}
+
+		for _, item := range result.Data {
+			covers = append(covers, Cover{ID: item.ID, Volume: item.Attributes.Volume, FileName: item.Attributes.FileName})
+		}
+
+		offset += limit
+		if offset >= result.Total {
+			break
+		}
+	}
+
+	return covers, nil
+}
+
+// coverDir returns where cover images are downloaded to. When
+// Config.SaveCovers is set, covers are kept alongside the volume archives
+// as a persistent side output; otherwise they're downloaded to the state
+// dir purely to be embedded in a volume's archive, and left for the next run
+// to reuse.
+func (c *Client) coverDir() string {
+	if c.Config.SaveCovers {
+		return filepath.Join(c.Config.Output, "covers")
+	}
+	return filepath.Join(c.stateDir(), "covers")
+}
+
+// ensureCoverDownloaded downloads cover if it isn't already on disk and
+// returns its local path.
+func (c *Client) ensureCoverDownloaded(ctx context.Context, mangaID string, cover Cover) (string, error) {
+	dir := c.coverDir()
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create covers dir: %w", err)
+	}
+
+	path := filepath.Join(dir, cover.FileName)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	coverURL := fmt.Sprintf("https://uploads.mangadex.org/covers/%s/%s", mangaID, cover.FileName)
+	// Covers are served from MangaDex's regular CDN, not an At-Home mirror,
+	// so they're never reported to the At-Home report endpoint.
+	if err := c.downloadImage(ctx, coverURL, path, false); err != nil {
+		return "", fmt.Errorf("failed to download cover %s: %w", cover.FileName, err)
+	}
+	return path, nil
+}
@@ -1,6 +1,8 @@
 package mangadex
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -12,10 +14,14 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/fbufler/mangadex/pkg/cbz"
-	"github.com/schollz/progressbar/v3"
+	"github.com/fbufler/mangadex/pkg/packer"
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+	"golang.org/x/sync/errgroup"
 )
 
 type MangaLanguage string
@@ -29,6 +35,14 @@ const (
 
 const RETRY_WAIT_TIME = 10 * time.Second
 
+// DefaultParallelChapters and DefaultParallelPages are used whenever the
+// corresponding Config field is left at its zero value, keeping the
+// sequential behaviour as the default.
+const (
+	DefaultParallelChapters = 1
+	DefaultParallelPages    = 1
+)
+
 type Config struct {
 	APIUrl        *url.URL
 	Timeout       time.Duration
@@ -39,12 +53,60 @@ type Config struct {
 	Name          string
 	Logger        *slog.Logger
 	Volume        string
+	// ParallelChapters bounds how many chapters are fetched and have their
+	// pages downloaded concurrently.
+	ParallelChapters int
+	// ParallelPages bounds how many pages within a single chapter are
+	// downloaded concurrently.
+	ParallelPages int
+	// CacheEnabled opts into caching API responses under
+	// os.UserCacheDir()/mangadex-loader, keyed by request URL.
+	CacheEnabled bool
+	// CacheTTL is how long a cached response stays valid. Zero means cached
+	// responses never expire.
+	CacheTTL time.Duration
+	// ChapterID downloads a single chapter instead of a whole manga. Set by
+	// resolving a chapter URL/UUID; the caller is responsible for confirming
+	// that's what the user wants (e.g. requiring an explicit --this flag
+	// when the target resolved to a chapter) before setting it.
+	ChapterID string
+	// Chapters filters GetChaptersByMangaID results to a numeric chapter
+	// range, e.g. "12-34".
+	Chapters string
+	// OutputFormat selects the archive format volumes are packaged into.
+	// Defaults to packer.FormatCBZ.
+	OutputFormat packer.Format
+	// SaveCovers keeps downloaded cover art under Output/covers as a side
+	// output instead of in the hidden per-manga state directory.
+	SaveCovers bool
+}
+
+// outputExtension returns the file extension matching Config.OutputFormat.
+func (c *Client) outputExtension() string {
+	switch c.Config.OutputFormat {
+	case packer.FormatCBR:
+		return "cbr"
+	case packer.FormatPDF:
+		return "pdf"
+	case packer.FormatEPUB:
+		return "epub"
+	default:
+		return "cbz"
+	}
 }
 
 type Client struct {
 	Config     *Config
 	logger     *slog.Logger
 	HttpClient *http.Client
+	// rateLimitUntil holds a UnixNano timestamp until which all workers must
+	// wait before issuing new requests. It is set whenever any worker hits a
+	// 429 response, so the backoff is shared across the whole worker pool.
+	rateLimitUntil atomic.Int64
+	// progress is the shared multi-bar renderer so that concurrently
+	// downloading chapters each get their own progress line.
+	progress *mpb.Progress
+	cache    *responseCache
 }
 
 func New(config *Config) *Client {
@@ -57,8 +119,8 @@ func New(config *Config) *Client {
 	if config.Timeout <= 0 {
 		panic("Timeout must be greater than 0")
 	}
-	if config.MangaID == "" {
-		panic("MangaID must be provided")
+	if config.MangaID == "" && config.ChapterID == "" {
+		panic("either MangaID or ChapterID must be provided")
 	}
 	if config.MangaLanguage == "" {
 		panic("MangaLanguage cannot be empty")
@@ -69,16 +131,28 @@ func New(config *Config) *Client {
 	if config.Name == "" {
 		panic("Name cannot be empty")
 	}
+	if config.ParallelChapters <= 0 {
+		config.ParallelChapters = DefaultParallelChapters
+	}
+	if config.ParallelPages <= 0 {
+		config.ParallelPages = DefaultParallelPages
+	}
 	httpClient := &http.Client{
 		Timeout: config.Timeout,
 		Transport: &http.Transport{
 			TLSNextProto: make(map[string]func(string, *tls.Conn) http.RoundTripper),
 		},
 	}
+	cache, err := newResponseCache(config.CacheEnabled, config.CacheTTL)
+	if err != nil {
+		panic(fmt.Sprintf("failed to set up response cache: %v", err))
+	}
 	return &Client{
 		Config:     config,
 		HttpClient: httpClient,
 		logger:     config.Logger,
+		progress:   mpb.New(mpb.WithWidth(40)),
+		cache:      cache,
 	}
 }
 
@@ -147,44 +221,104 @@ func (c *Client) fetchChapterMetadata(chapterID string) (*ChapterMetadata, error
 	}, nil
 }
 
-func (c *Client) groupChaptersByVolume(chapterIDs []string) (map[string][]string, error) {
+// chapterDownload is the outcome of downloading a single chapter, keyed by
+// its position in the original chapterIDs slice so results can be merged
+// back into a deterministic order once every worker has finished.
+type chapterDownload struct {
+	volume  string
+	dir     string
+	skipped bool
+}
+
+func (c *Client) groupChaptersByVolume(ctx context.Context, chapterIDs []string) (map[string][]string, error) {
 	safe := func(s string) string {
 		return stringReplaceAllRune(s, '/', '_')
 	}
+
+	existingVolumes, err := c.existingVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]chapterDownload, len(chapterIDs))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(c.Config.ParallelChapters)
+
+	for i, id := range chapterIDs {
+		eg.Go(func() error {
+			meta, err := c.fetchChapterMetadata(id)
+			if err != nil {
+				return err
+			}
+			safeVolume := safe(meta.Volume)
+			if c.Config.Volume != "" && safeVolume != c.Config.Volume {
+				c.logger.Info("Skipping chapter not in requested volume", "chapter", id, "volume", safeVolume)
+				results[i] = chapterDownload{skipped: true}
+				return nil
+			}
+			if existingVolumes[safeVolume] {
+				c.logger.Info("Volume already downloaded, skipping chapter", "chapter", id, "volume", safeVolume)
+				results[i] = chapterDownload{skipped: true}
+				return nil
+			}
+			tmpDir, err := c.downloadChapterPages(ctx, id)
+			if err != nil {
+				return err
+			}
+			c.logger.Info("Chapter downloaded", "chapter", id, "volume", safeVolume)
+			results[i] = chapterDownload{volume: safeVolume, dir: tmpDir}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Merge in original chapter order so volume archives are always written
+	// with the same page ordering regardless of download scheduling.
 	volumeDirs := make(map[string][]string)
-	for _, id := range chapterIDs {
-		meta, err := c.fetchChapterMetadata(id)
-		if err != nil {
-			return nil, err
-		}
-		safeVolume := safe(meta.Volume)
-		if c.Config.Volume != "" && safeVolume != c.Config.Volume {
-			c.logger.Info("Skipping chapter not in requested volume", "chapter", id, "volume", safeVolume)
+	for _, result := range results {
+		if result.skipped {
 			continue
 		}
-		tmpDir, err := c.downloadChapterPages(id)
-		if err != nil {
-			return nil, err
-		}
-		volumeDirs[safeVolume] = append(volumeDirs[safeVolume], tmpDir)
-		c.logger.Info("Chapter downloaded", "chapter", id, "volume", safeVolume)
+		volumeDirs[result.volume] = append(volumeDirs[result.volume], result.dir)
 	}
 	return volumeDirs, nil
 }
 
 func (c *Client) DownloadManga() error {
+	ctx := context.Background()
 	chapterIDs, err := c.GetChaptersByMangaID(c.Config.MangaID, "en")
 	if err != nil {
 		return fmt.Errorf("failed to get chapter IDs: %w", err)
 	}
-	volumeDirs, err := c.groupChaptersByVolume(chapterIDs)
+	volumeDirs, err := c.groupChaptersByVolume(ctx, chapterIDs)
 	if err != nil {
 		return err
 	}
+	mangaMeta, err := c.GetMangaMetadata(c.Config.MangaID)
+	if err != nil {
+		return fmt.Errorf("failed to get manga metadata: %w", err)
+	}
+	covers, err := c.GetMangaCovers(c.Config.MangaID)
+	if err != nil {
+		return fmt.Errorf("failed to get manga covers: %w", err)
+	}
+	coverByVolume := make(map[string]Cover, len(covers))
+	for _, cover := range covers {
+		coverByVolume[stringReplaceAllRune(cover.Volume, '/', '_')] = cover
+	}
 	for volume, dirs := range volumeDirs {
-		filename := fmt.Sprintf("%s-volume-%s.cbz", c.Config.Name, volume)
+		filename := fmt.Sprintf("%s-volume-%s.%s", c.Config.Name, volume, c.outputExtension())
 		outPath := filepath.Join(c.Config.Output, filename)
-		err := c.compressVolumeToCBZ(volume, dirs, outPath)
+		var coverPath string
+		if cover, ok := coverByVolume[volume]; ok {
+			coverPath, err = c.ensureCoverDownloaded(ctx, c.Config.MangaID, cover)
+			if err != nil {
+				return fmt.Errorf("failed to get cover for volume %s: %w", volume, err)
+			}
+		}
+		err := c.compressVolumeToCBZ(volume, dirs, outPath, mangaMeta, volume, "", coverPath)
 		if err != nil {
 			return fmt.Errorf("failed to compress volume %s: %w", volume, err)
 		}
@@ -195,6 +329,35 @@ func (c *Client) DownloadManga() error {
 		}
 		c.logger.Info("Removed temp directories", "volume", volume)
 	}
+	c.progress.Wait()
+	return nil
+}
+
+// DownloadChapter downloads a single chapter into its own archive, in
+// Config.OutputFormat, for use with
+// a chapter Target rather than downloading a whole manga.
+func (c *Client) DownloadChapter(chapterID string) error {
+	ctx := context.Background()
+	meta, err := c.fetchChapterMetadata(chapterID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch chapter metadata: %w", err)
+	}
+	dir, err := c.downloadChapterPages(ctx, chapterID)
+	if err != nil {
+		return fmt.Errorf("failed to download chapter %s: %w", chapterID, err)
+	}
+	safeChapter := stringReplaceAllRune(meta.Chapter, '/', '_')
+	filename := fmt.Sprintf("%s-chapter-%s.%s", c.Config.Name, safeChapter, c.outputExtension())
+	outPath := filepath.Join(c.Config.Output, filename)
+	if err := c.compressVolumeToCBZ(meta.Chapter, []string{dir}, outPath, nil, meta.Chapter, meta.Title, ""); err != nil {
+		return fmt.Errorf("failed to compress chapter %s: %w", chapterID, err)
+	}
+	c.logger.Info("Chapter compressed", "chapter", chapterID, "file", filename)
+	if err := c.removeTempDirectories([]string{dir}); err != nil {
+		return fmt.Errorf("failed to remove temp directory for chapter %s: %w", chapterID, err)
+	}
+	c.logger.Info("Removed temp directory", "chapter", chapterID)
+	c.progress.Wait()
 	return nil
 }
 
@@ -214,6 +377,16 @@ func (c *Client) GetChaptersByMangaID(mangaID, lang string) ([]string, error) {
 	limit := 100
 	offset := 0
 
+	var chapterLo, chapterHi float64
+	filterByChapter := c.Config.Chapters != ""
+	if filterByChapter {
+		var err error
+		chapterLo, chapterHi, err = parseChapterRange(c.Config.Chapters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for {
 		volumeParam := ""
 		if c.Config.Volume != "" {
@@ -258,6 +431,12 @@ func (c *Client) GetChaptersByMangaID(mangaID, lang string) ([]string, error) {
 		})
 
 		for _, item := range result.Data {
+			if filterByChapter {
+				chapterNum, err := strconv.ParseFloat(item.Attributes.Chapter, 64)
+				if err != nil || chapterNum < chapterLo || chapterNum > chapterHi {
+					continue
+				}
+			}
 			allChapterIDs = append(allChapterIDs, item.ID)
 		}
 
@@ -300,80 +479,71 @@ func (c *Client) getChapterImageData(chapterId string) (*ChapterImageData, error
 	}, nil
 }
 
-func (c *Client) downloadImage(url, outputPath string) error {
-	resp, err := c.makeRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to download image: %w", err)
-	}
-	defer resp.Body.Close()
-
-	out, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create image file: %w", err)
-	}
-	defer out.Close()
-
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to save image: %w", err)
+func (c *Client) downloadChapterPages(ctx context.Context, chapterId string) (string, error) {
+	chapterDir := c.chapterStateDir(chapterId)
+	if c.chapterDownloaded(chapterId) {
+		c.logger.Info("Chapter already downloaded, resuming", "chapter", chapterId)
+		return chapterDir, nil
 	}
 
-	return nil
-}
-
-func (c *Client) downloadChapterPages(chapterId string) (string, error) {
 	imageData, err := c.getChapterImageData(chapterId)
 	if err != nil {
 		return "", err
 	}
 
-	tmpDir, err := os.MkdirTemp("", "mangadex")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	if err := os.MkdirAll(chapterDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create chapter dir: %w", err)
 	}
 
-	bar := progressbar.NewOptions(len(imageData.Data),
-		progressbar.OptionSetDescription("Downloading"),
-		progressbar.OptionShowCount(),
-		progressbar.OptionClearOnFinish(),
-		progressbar.OptionSetWidth(40),
+	bar := c.progress.AddBar(int64(len(imageData.Data)),
+		mpb.PrependDecorators(decor.Name(chapterId, decor.WC{W: len(chapterId) + 1, C: decor.DindentRight})),
+		mpb.AppendDecorators(decor.CountersNoUnit("%d / %d")),
 	)
 
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(c.Config.ParallelPages)
 	for i, file := range imageData.Data {
-		imageURL := fmt.Sprintf("%s/data/%s/%s", imageData.BaseURL, imageData.Hash, file)
-		c.logger.Debug("Downloading image", "url", imageURL)
+		eg.Go(func() error {
+			imageURL := fmt.Sprintf("%s/data/%s/%s", imageData.BaseURL, imageData.Hash, file)
+			c.logger.Debug("Downloading image", "url", imageURL)
 
-		filename := fmt.Sprintf("%s/%03d_%s", tmpDir, i+1, file)
-		err := c.downloadImage(imageURL, filename)
-		if err != nil {
-			return "", err
-		}
+			filename := fmt.Sprintf("%s/%03d_%s", chapterDir, i+1, file)
+			if err := c.downloadImage(ctx, imageURL, filename, true); err != nil {
+				return err
+			}
+
+			c.logger.Debug("Saved image", "file", filename)
+			bar.Increment()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
 
-		c.logger.Debug("Saved image", "file", filename)
-		_ = bar.Add(1)
+	if err := c.markChapterDownloaded(chapterId); err != nil {
+		return "", fmt.Errorf("failed to mark chapter as downloaded: %w", err)
 	}
 
-	return tmpDir, nil
+	return chapterDir, nil
 }
 
-func (c *Client) compressVolumeToCBZ(volumeName string, chapterDirectories []string, outPath string) error {
-	c.logger.Debug("Compressing to CBZ", "volumeName", volumeName, "outPath", outPath)
-	cbzFile, err := cbz.Open(outPath, c.logger)
-	if err != nil {
-		return fmt.Errorf("failed to open CBZ file: %w", err)
-	}
+// compressVolumeToCBZ writes chapterDirectories into an archive of
+// Config.OutputFormat at outPath, handing the packager ComicInfo.xml
+// metadata built from mangaMeta plus the given number/title (embedded only
+// where the format supports it). mangaMeta may be nil when manga-level
+// metadata isn't available (e.g. single-chapter downloads).
+func (c *Client) compressVolumeToCBZ(volumeName string, chapterDirectories []string, outPath string, mangaMeta *MangaMetadata, number, title, coverPath string) error {
+	c.logger.Debug("Compressing volume", "volumeName", volumeName, "outPath", outPath, "format", c.Config.OutputFormat)
 
+	var pageFiles []string
 	for _, dir := range chapterDirectories {
-		err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
 			if !info.IsDir() {
-				file, err := os.Open(path)
-				if err != nil {
-					return fmt.Errorf("failed to open file %s: %w", path, err)
-				}
-				cbzFile.Add(file)
+				pageFiles = append(pageFiles, path)
 			}
 			return nil
 		})
@@ -381,7 +551,35 @@ func (c *Client) compressVolumeToCBZ(volumeName string, chapterDirectories []str
 			return err
 		}
 	}
-	return cbzFile.Write(&cbz.WriteOptions{Order: true})
+
+	hasCover := coverPath != ""
+	if hasCover {
+		pageFiles = append([]string{coverPath}, pageFiles...)
+	}
+
+	metadata := c.buildCBZMetadata(mangaMeta, volumeName, number, title, pageFiles)
+	pkgr, err := packer.New(c.Config.OutputFormat, outPath, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to create packager: %w", err)
+	}
+
+	for i, path := range pageFiles {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open file %s: %w", path, err)
+		}
+		entryName := fmt.Sprintf("%03d_%s", i, filepath.Base(path))
+		if hasCover && i == 0 {
+			entryName = "000_cover.jpg"
+		}
+		err = pkgr.Add(entryName, file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", path, err)
+		}
+	}
+
+	return pkgr.Close()
 }
 
 func (c *Client) removeTempDirectories(chapterDirectories []string) error {
@@ -390,11 +588,71 @@ func (c *Client) removeTempDirectories(chapterDirectories []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to remove temp directory %s: %w", dir, err)
 		}
+		chapterID := filepath.Base(dir)
+		if err := os.Remove(c.chapterMarkerPath(chapterID)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove marker for chapter %s: %w", chapterID, err)
+		}
 	}
 	return nil
 }
 
+// awaitRateLimit blocks until any rate limit set by a concurrent worker has
+// elapsed, so all workers back off together instead of hammering the API
+// again the instant one of them gets a 429.
+func (c *Client) awaitRateLimit() {
+	until := c.rateLimitUntil.Load()
+	if until == 0 {
+		return
+	}
+	if wait := time.Until(time.Unix(0, until)); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (c *Client) triggerRateLimit(wait time.Duration) {
+	c.rateLimitUntil.Store(time.Now().Add(wait).UnixNano())
+}
+
+// cacheableURLPrefixes are the API endpoints eligible for the response
+// cache: chapter listings/metadata, manga metadata, and cover listings.
+// /at-home/server/{id} is deliberately excluded: its response embeds a
+// short-lived, signed image-host token, and caching it would make resumed
+// downloads replay a dead token against the CDN until they exhaust their
+// retries.
+var cacheableURLPrefixes = []string{"/chapter", "/manga", "/cover"}
+
+// cacheableURL reports whether url is one of cacheableURLPrefixes under
+// Config.APIUrl. Image downloads are served from a different host and are
+// never cached.
+func (c *Client) cacheableURL(url string) bool {
+	base := c.Config.APIUrl.String()
+	if !strings.HasPrefix(url, base) {
+		return false
+	}
+	path := strings.TrimPrefix(url, base)
+	for _, prefix := range cacheableURLPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) makeRequest(method, url string, body io.Reader) (*http.Response, error) {
+	cacheable := method == http.MethodGet && c.cacheableURL(url)
+	if cacheable {
+		if data, ok := c.cache.get(url); ok {
+			c.logger.Debug("Cache hit", "url", url)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     http.StatusText(http.StatusOK),
+				Body:       io.NopCloser(bytes.NewReader(data)),
+				Header:     make(http.Header),
+			}, nil
+		}
+	}
+
+	c.awaitRateLimit()
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -408,10 +666,24 @@ func (c *Client) makeRequest(method, url string, body io.Reader) (*http.Response
 		// Handle rate limiting
 		if resp.StatusCode == http.StatusTooManyRequests {
 			c.logger.Warn("Rate limit reached, waiting 5 seconds")
-			time.Sleep(5 * time.Second)
-			return c.retryRequest(req, c.Config.Retries)
+			c.triggerRateLimit(5 * time.Second)
+			c.awaitRateLimit()
+			resp, err = c.retryRequest(req, c.Config.Retries)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, fmt.Errorf("request failed with status %s", resp.Status)
 		}
-		return nil, fmt.Errorf("request failed with status %s", resp.Status)
+	}
+	if cacheable {
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		c.cache.put(url, data)
+		resp.Body = io.NopCloser(bytes.NewReader(data))
 	}
 	return resp, nil
 }
@@ -421,10 +693,15 @@ func (c *Client) retryRequest(req *http.Request, maxRetries int) (*http.Response
 	var err error
 
 	for i := range maxRetries {
+		c.awaitRateLimit()
 		resp, err = c.HttpClient.Do(req)
 		if err == nil && resp.StatusCode == http.StatusOK {
 			return resp, nil
 		}
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			c.logger.Warn("Rate limit reached, waiting 5 seconds")
+			c.triggerRateLimit(5 * time.Second)
+		}
 		c.logger.Warn("Request failed, retrying", "attempt", i+1, "error", err)
 		time.Sleep(RETRY_WAIT_TIME)
 	}
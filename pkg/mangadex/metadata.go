@@ -0,0 +1,132 @@
+package mangadex
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	"github.com/fbufler/mangadex/pkg/cbz"
+)
+
+// MangaMetadata is the subset of /manga/{id} attributes needed to populate
+// a ComicInfo.xml, aggregated from the manga's expanded author/artist
+// relationships and tags.
+type MangaMetadata struct {
+	Title       string
+	Description string
+	Authors     []string
+	Artists     []string
+	Tags        []string
+}
+
+// GetMangaMetadata fetches manga attributes and its expanded author/artist
+// relationships from /manga/{id}.
+func (c *Client) GetMangaMetadata(mangaID string) (*MangaMetadata, error) {
+	apiURL := fmt.Sprintf("%s/manga/%s?includes[]=author&includes[]=artist", c.Config.APIUrl.String(), mangaID)
+	resp, err := c.makeRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manga metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			Attributes struct {
+				Title       map[string]string `json:"title"`
+				Description map[string]string `json:"description"`
+				Tags        []struct {
+					Attributes struct {
+						Name map[string]string `json:"name"`
+					} `json:"attributes"`
+				} `json:"tags"`
+			} `json:"attributes"`
+			Relationships []struct {
+				Type       string `json:"type"`
+				Attributes struct {
+					Name string `json:"name"`
+				} `json:"attributes"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode manga metadata: %w", err)
+	}
+
+	meta := &MangaMetadata{
+		Title:       result.Data.Attributes.Title["en"],
+		Description: result.Data.Attributes.Description["en"],
+	}
+	for _, tag := range result.Data.Attributes.Tags {
+		if name := tag.Attributes.Name["en"]; name != "" {
+			meta.Tags = append(meta.Tags, name)
+		}
+	}
+	for _, rel := range result.Data.Relationships {
+		switch rel.Type {
+		case "author":
+			meta.Authors = append(meta.Authors, rel.Attributes.Name)
+		case "artist":
+			meta.Artists = append(meta.Artists, rel.Attributes.Name)
+		}
+	}
+	return meta, nil
+}
+
+// buildCBZMetadata maps aggregated manga/chapter attributes onto a
+// cbz.Metadata ready to be embedded as ComicInfo.xml. pageFiles is the
+// ordered list of page image paths that will be written to the archive.
+func (c *Client) buildCBZMetadata(mangaMeta *MangaMetadata, volume, number, title string, pageFiles []string) *cbz.Metadata {
+	metadata := &cbz.Metadata{
+		Volume:      volume,
+		Number:      number,
+		Title:       title,
+		LanguageISO: string(c.Config.MangaLanguage),
+		PageCount:   len(pageFiles),
+		Pages:       pagesFromFiles(pageFiles),
+	}
+	if mangaMeta != nil {
+		metadata.Series = mangaMeta.Title
+		metadata.Summary = mangaMeta.Description
+		metadata.Writer = strings.Join(mangaMeta.Authors, ", ")
+		metadata.Penciller = strings.Join(mangaMeta.Artists, ", ")
+		metadata.Genre = strings.Join(mangaMeta.Tags, ", ")
+	}
+	return metadata
+}
+
+// pagesFromFiles builds ComicInfo.xml Page entries by reading each image's
+// dimensions off disk. The first page is marked as the front cover; a file
+// whose dimensions can't be read is still included with Width/Height left
+// at zero.
+func pagesFromFiles(pageFiles []string) []cbz.Page {
+	pages := make([]cbz.Page, len(pageFiles))
+	for i, path := range pageFiles {
+		page := cbz.Page{Image: i, Type: cbz.PageTypeStory}
+		if i == 0 {
+			page.Type = cbz.PageTypeFrontCover
+		}
+		if width, height, err := imageDimensions(path); err == nil {
+			page.Width = width
+			page.Height = height
+		}
+		pages[i] = page
+	}
+	return pages
+}
+
+func imageDimensions(path string) (width, height int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	cfg, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
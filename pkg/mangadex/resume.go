@@ -0,0 +1,59 @@
+package mangadex
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stateDir is where per-chapter download markers live so an interrupted run
+// can resume instead of starting over.
+func (c *Client) stateDir() string {
+	return filepath.Join(c.Config.Output, ".mangadex-state", c.Config.Name)
+}
+
+func (c *Client) chapterStateDir(chapterID string) string {
+	return filepath.Join(c.stateDir(), "chapters", chapterID)
+}
+
+func (c *Client) chapterMarkerPath(chapterID string) string {
+	return filepath.Join(c.stateDir(), "chapters", chapterID+".done")
+}
+
+// existingVolumes returns the set of volume names that already have a
+// completed archive of Config.OutputFormat in the output directory, so
+// DownloadManga can skip
+// re-downloading their chapters entirely.
+func (c *Client) existingVolumes() (map[string]bool, error) {
+	extension := "." + c.outputExtension()
+	pattern := filepath.Join(c.Config.Output, fmt.Sprintf("%s-volume-*%s", c.Config.Name, extension))
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing volumes: %w", err)
+	}
+	prefix := fmt.Sprintf("%s-volume-", c.Config.Name)
+	existing := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		base := strings.TrimSuffix(filepath.Base(match), extension)
+		existing[strings.TrimPrefix(base, prefix)] = true
+	}
+	return existing, nil
+}
+
+// chapterDownloaded reports whether chapterID was already fully downloaded
+// in a previous run.
+func (c *Client) chapterDownloaded(chapterID string) bool {
+	_, err := os.Stat(c.chapterMarkerPath(chapterID))
+	return err == nil
+}
+
+// markChapterDownloaded records that chapterID finished downloading, so a
+// later run can skip it if the enclosing volume is interrupted before being
+// compressed.
+func (c *Client) markChapterDownloaded(chapterID string) error {
+	if err := os.MkdirAll(filepath.Dir(c.chapterMarkerPath(chapterID)), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	return os.WriteFile(c.chapterMarkerPath(chapterID), []byte{}, 0o644)
+}
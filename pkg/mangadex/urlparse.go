@@ -0,0 +1,89 @@
+package mangadex
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TargetKind identifies what kind of MangaDex resource a Target points at.
+type TargetKind string
+
+const (
+	// TargetKindTitle is a manga/title resource.
+	TargetKindTitle TargetKind = "title"
+	// TargetKindChapter is a single chapter resource.
+	TargetKindChapter TargetKind = "chapter"
+)
+
+// Target is a resolved MangaDex resource reference, either a bare UUID
+// (assumed to be a title) or parsed out of a full MangaDex URL.
+type Target struct {
+	Kind TargetKind
+	UUID string
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ParseTarget accepts either a bare MangaDex UUID (treated as a title ID,
+// preserving the original CLI behaviour) or a full MangaDex URL such as
+// mangadex.org/title/<uuid> or mangadex.org/chapter/<uuid>, and returns the
+// kind of resource it points at along with its UUID.
+func ParseTarget(input string) (*Target, error) {
+	input = strings.TrimSpace(input)
+	if uuidPattern.MatchString(input) {
+		return &Target{Kind: TargetKindTitle, UUID: input}, nil
+	}
+
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MangaDex URL %q: %w", input, err)
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("unrecognized MangaDex URL %q", input)
+	}
+
+	var kind TargetKind
+	switch parts[0] {
+	case "title":
+		kind = TargetKindTitle
+	case "chapter":
+		kind = TargetKindChapter
+	default:
+		return nil, fmt.Errorf("unsupported MangaDex URL kind %q in %q", parts[0], input)
+	}
+
+	uuid := parts[1]
+	if !uuidPattern.MatchString(uuid) {
+		return nil, fmt.Errorf("invalid UUID %q in MangaDex URL %q", uuid, input)
+	}
+
+	return &Target{Kind: kind, UUID: uuid}, nil
+}
+
+// parseChapterRange parses a "--chapters" spec like "12-34" into bounds
+// suitable for filtering ChapterListResponse entries by their numeric
+// chapter attribute.
+func parseChapterRange(spec string) (lo, hi float64, err error) {
+	parts := strings.SplitN(spec, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid chapter range %q, expected format like 12-34", spec)
+	}
+	lo, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chapter range %q: %w", spec, err)
+	}
+	hi, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chapter range %q: %w", spec, err)
+	}
+	return lo, hi, nil
+}
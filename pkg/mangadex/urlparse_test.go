@@ -0,0 +1,128 @@
+package mangadex
+
+import "testing"
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Target
+		wantErr bool
+	}{
+		{
+			name:  "bare UUID is treated as a title",
+			input: "a1b2c3d4-e5f6-7890-abcd-ef0123456789",
+			want:  Target{Kind: TargetKindTitle, UUID: "a1b2c3d4-e5f6-7890-abcd-ef0123456789"},
+		},
+		{
+			name:  "title URL without scheme",
+			input: "mangadex.org/title/a1b2c3d4-e5f6-7890-abcd-ef0123456789",
+			want:  Target{Kind: TargetKindTitle, UUID: "a1b2c3d4-e5f6-7890-abcd-ef0123456789"},
+		},
+		{
+			name:  "chapter URL with scheme",
+			input: "https://mangadex.org/chapter/a1b2c3d4-e5f6-7890-abcd-ef0123456789",
+			want:  Target{Kind: TargetKindChapter, UUID: "a1b2c3d4-e5f6-7890-abcd-ef0123456789"},
+		},
+		{
+			name:    "malformed UUID",
+			input:   "not-a-uuid",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported URL kind",
+			input:   "mangadex.org/group/a1b2c3d4-e5f6-7890-abcd-ef0123456789",
+			wantErr: true,
+		},
+		{
+			name:    "URL with invalid UUID segment",
+			input:   "mangadex.org/title/not-a-uuid",
+			wantErr: true,
+		},
+		{
+			name:    "URL with too few path segments",
+			input:   "mangadex.org/title",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTarget(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTarget(%q) = %+v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTarget(%q) returned unexpected error: %v", tt.input, err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseTarget(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChapterRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantLo  float64
+		wantHi  float64
+		wantErr bool
+	}{
+		{
+			name:   "simple integer range",
+			spec:   "12-34",
+			wantLo: 12,
+			wantHi: 34,
+		},
+		{
+			name:   "decimal chapter numbers",
+			spec:   "1.5-2.5",
+			wantLo: 1.5,
+			wantHi: 2.5,
+		},
+		{
+			name:   "inverted range is parsed as-is",
+			spec:   "34-12",
+			wantLo: 34,
+			wantHi: 12,
+		},
+		{
+			name:    "missing separator",
+			spec:    "12",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric bound",
+			spec:    "abc-12",
+			wantErr: true,
+		},
+		{
+			name:    "empty spec",
+			spec:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, err := parseChapterRange(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseChapterRange(%q) = (%v, %v), want error", tt.spec, lo, hi)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseChapterRange(%q) returned unexpected error: %v", tt.spec, err)
+			}
+			if lo != tt.wantLo || hi != tt.wantHi {
+				t.Errorf("parseChapterRange(%q) = (%v, %v), want (%v, %v)", tt.spec, lo, hi, tt.wantLo, tt.wantHi)
+			}
+		})
+	}
+}
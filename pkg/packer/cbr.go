@@ -0,0 +1,67 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/fbufler/mangadex/pkg/cbz"
+)
+
+// cbrPackager stages entries on disk and shells out to an external `rar`
+// binary on Close, since Go has no native RAR encoder.
+type cbrPackager struct {
+	outPath  string
+	tmpDir   string
+	names    []string
+	metadata *cbz.Metadata
+}
+
+func newCBRPackager(outPath string, metadata *cbz.Metadata) (*cbrPackager, error) {
+	tmpDir, err := os.MkdirTemp("", "mangadex-cbr")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CBR staging dir: %w", err)
+	}
+	return &cbrPackager{outPath: outPath, tmpDir: tmpDir, metadata: metadata}, nil
+}
+
+func (p *cbrPackager) Add(name string, r io.Reader) error {
+	path := filepath.Join(p.tmpDir, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to stage %s: %w", name, err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	p.names = append(p.names, name)
+	return nil
+}
+
+func (p *cbrPackager) Close() error {
+	defer os.RemoveAll(p.tmpDir)
+	if p.metadata != nil {
+		data, err := p.metadata.Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to marshal ComicInfo.xml: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(p.tmpDir, "ComicInfo.xml"), data, 0o644); err != nil {
+			return fmt.Errorf("failed to stage ComicInfo.xml: %w", err)
+		}
+		p.names = append(p.names, "ComicInfo.xml")
+	}
+	if err := os.MkdirAll(filepath.Dir(p.outPath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create output dir for %s: %w", p.outPath, err)
+	}
+	args := append([]string{"a", "-ep1", p.outPath}, p.names...)
+	cmd := exec.Command("rar", args...)
+	cmd.Dir = p.tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("rar failed: %w: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package packer
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fbufler/mangadex/pkg/cbz"
+)
+
+type cbzPackager struct {
+	file      *os.File
+	zipWriter *zip.Writer
+	metadata  *cbz.Metadata
+}
+
+func newCBZPackager(outPath string, metadata *cbz.Metadata) (*cbzPackager, error) {
+	if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create output dir for %s: %w", outPath, err)
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CBZ file: %w", err)
+	}
+	return &cbzPackager{file: file, zipWriter: zip.NewWriter(file), metadata: metadata}, nil
+}
+
+func (p *cbzPackager) Add(name string, r io.Reader) error {
+	writer, err := p.zipWriter.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to CBZ: %w", name, err)
+	}
+	if _, err := io.Copy(writer, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (p *cbzPackager) Close() error {
+	if p.metadata != nil {
+		data, err := p.metadata.Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to marshal ComicInfo.xml: %w", err)
+		}
+		writer, err := p.zipWriter.Create("ComicInfo.xml")
+		if err != nil {
+			return fmt.Errorf("failed to create ComicInfo.xml entry: %w", err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("failed to write ComicInfo.xml: %w", err)
+		}
+	}
+	if err := p.zipWriter.Close(); err != nil {
+		return err
+	}
+	return p.file.Close()
+}
@@ -0,0 +1,165 @@
+package packer
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fbufler/mangadex/pkg/cbz"
+)
+
+// epubPackager wraps each page image in its own XHTML spine entry, which is
+// the simplest layout e-readers reliably render for image-only content.
+type epubPackager struct {
+	file      *os.File
+	zipWriter *zip.Writer
+	metadata  *cbz.Metadata
+	pages     []string
+}
+
+func newEPUBPackager(outPath string, metadata *cbz.Metadata) (*epubPackager, error) {
+	if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create output dir for %s: %w", outPath, err)
+	}
+	file, err := os.Create(outPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EPUB file: %w", err)
+	}
+	zipWriter := zip.NewWriter(file)
+
+	// The mimetype entry must be first and stored uncompressed, per the
+	// EPUB OCF spec.
+	writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write EPUB mimetype: %w", err)
+	}
+	if _, err := writer.Write([]byte("application/epub+zip")); err != nil {
+		return nil, fmt.Errorf("failed to write EPUB mimetype: %w", err)
+	}
+
+	return &epubPackager{file: file, zipWriter: zipWriter, metadata: metadata}, nil
+}
+
+func (p *epubPackager) Add(name string, r io.Reader) error {
+	imageWriter, err := p.zipWriter.Create("OEBPS/images/" + name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to EPUB: %w", name, err)
+	}
+	if _, err := io.Copy(imageWriter, r); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	pageID := fmt.Sprintf("page-%03d", len(p.pages)+1)
+	escapedName := escapeXML(name)
+	page := fmt.Sprintf(epubPageXHTML, escapedName, escapedName, escapedName)
+	pageWriter, err := p.zipWriter.Create(fmt.Sprintf("OEBPS/%s.xhtml", pageID))
+	if err != nil {
+		return fmt.Errorf("failed to add page %s: %w", pageID, err)
+	}
+	if _, err := pageWriter.Write([]byte(page)); err != nil {
+		return fmt.Errorf("failed to write page %s: %w", pageID, err)
+	}
+
+	p.pages = append(p.pages, name)
+	return nil
+}
+
+func (p *epubPackager) Close() error {
+	if err := p.writeContainer(); err != nil {
+		return err
+	}
+	if err := p.writeContentOPF(); err != nil {
+		return err
+	}
+	if err := p.zipWriter.Close(); err != nil {
+		return err
+	}
+	return p.file.Close()
+}
+
+func (p *epubPackager) writeContainer() error {
+	writer, err := p.zipWriter.Create("META-INF/container.xml")
+	if err != nil {
+		return fmt.Errorf("failed to write EPUB container: %w", err)
+	}
+	_, err = writer.Write([]byte(epubContainerXML))
+	return err
+}
+
+func (p *epubPackager) writeContentOPF() error {
+	title := "Untitled"
+	if p.metadata != nil && p.metadata.Series != "" {
+		title = p.metadata.Series
+	}
+
+	var manifest, spine strings.Builder
+	for i, name := range p.pages {
+		id := fmt.Sprintf("page-%03d", i+1)
+		imageID := fmt.Sprintf("%s-image", id)
+		fmt.Fprintf(&manifest, "    <item id=\"%s\" href=\"%s.xhtml\" media-type=\"application/xhtml+xml\"/>\n", id, id)
+		fmt.Fprintf(&manifest, "    <item id=\"%s\" href=\"images/%s\" media-type=\"%s\"/>\n", imageID, escapeXML(name), imageMediaType(name))
+		fmt.Fprintf(&spine, "    <itemref idref=\"%s\"/>\n", id)
+	}
+
+	content := fmt.Sprintf(epubContentOPF, escapeXML(title), manifest.String(), spine.String())
+	writer, err := p.zipWriter.Create("OEBPS/content.opf")
+	if err != nil {
+		return fmt.Errorf("failed to write EPUB content.opf: %w", err)
+	}
+	_, err = writer.Write([]byte(content))
+	return err
+}
+
+// escapeXML escapes s for safe interpolation into the XML/XHTML templates
+// below. Manga titles and page filenames are attacker-adjacent free text
+// (pulled from the MangaDex API or chapter archives) and routinely contain
+// "&", "<", or "\"", any of which would otherwise produce malformed markup.
+func escapeXML(s string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
+
+// imageMediaType returns the OPF manifest media-type for name, based on its
+// extension, defaulting to JPEG since that's what MangaDex serves pages as.
+func imageMediaType(name string) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return "image/jpeg"
+}
+
+const epubContainerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const epubPageXHTML = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head><title>%s</title></head>
+<body><img src="images/%s" alt="%s"/></body>
+</html>
+`
+
+const epubContentOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" unique-identifier="BookId" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>%s</dc:title>
+    <dc:language>en</dc:language>
+  </metadata>
+  <manifest>
+%s  </manifest>
+  <spine>
+%s  </spine>
+</package>
+`
@@ -0,0 +1,54 @@
+// Package packer abstracts over the archive formats a downloaded volume can
+// be written to, so callers can add pages in order without caring whether
+// the output ends up as a CBZ, CBR, PDF, or EPUB.
+package packer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fbufler/mangadex/pkg/cbz"
+)
+
+// Format identifies which on-disk archive format a Packager produces.
+type Format string
+
+const (
+	// FormatCBZ is a plain zip archive of page images (the default).
+	FormatCBZ Format = "cbz"
+	// FormatCBR shells out to an external `rar` binary to produce a RAR
+	// archive of page images.
+	FormatCBR Format = "cbr"
+	// FormatPDF lays out one page image per PDF page.
+	FormatPDF Format = "pdf"
+	// FormatEPUB wraps each page image in its own XHTML spine entry.
+	FormatEPUB Format = "epub"
+)
+
+// Packager collects named page entries, in the order they should appear in
+// the output, and flushes them into a single archive on Close.
+type Packager interface {
+	// Add appends an entry read fully from r under name.
+	Add(name string, r io.Reader) error
+	// Close flushes the archive to disk. It must be called exactly once,
+	// after all entries have been added.
+	Close() error
+}
+
+// New constructs the Packager for format, writing to outPath. metadata is
+// embedded as ComicInfo.xml (or the closest equivalent) where the format
+// supports it, and may be nil.
+func New(format Format, outPath string, metadata *cbz.Metadata) (Packager, error) {
+	switch format {
+	case FormatCBZ, "":
+		return newCBZPackager(outPath, metadata)
+	case FormatCBR:
+		return newCBRPackager(outPath, metadata)
+	case FormatPDF:
+		return newPDFPackager(outPath)
+	case FormatEPUB:
+		return newEPUBPackager(outPath, metadata)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+}
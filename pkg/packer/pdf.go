@@ -0,0 +1,62 @@
+package packer
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// pdfPackager lays out one page image per PDF page, sized to the image's
+// own pixel dimensions so pages aren't stretched or letterboxed.
+type pdfPackager struct {
+	outPath string
+	pdf     *gofpdf.Fpdf
+	count   int
+}
+
+func newPDFPackager(outPath string) (*pdfPackager, error) {
+	return &pdfPackager{outPath: outPath, pdf: gofpdf.New("P", "pt", "", "")}, nil
+}
+
+func (p *pdfPackager) Add(name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode image %s: %w", name, err)
+	}
+	imageType := "JPG"
+	if format == "png" {
+		imageType = "PNG"
+	}
+
+	p.count++
+	imageOpts := gofpdf.ImageOptions{ImageType: imageType}
+	imageName := fmt.Sprintf("page-%03d", p.count)
+	p.pdf.RegisterImageOptionsReader(imageName, imageOpts, bytes.NewReader(data))
+
+	width, height := float64(cfg.Width), float64(cfg.Height)
+	p.pdf.AddPageFormat("P", gofpdf.SizeType{Wd: width, Ht: height})
+	p.pdf.ImageOptions(imageName, 0, 0, width, height, false, imageOpts, 0, "")
+	return nil
+}
+
+func (p *pdfPackager) Close() error {
+	if err := p.pdf.Error(); err != nil {
+		return fmt.Errorf("failed to build PDF: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(p.outPath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create output dir for %s: %w", p.outPath, err)
+	}
+	return p.pdf.OutputFileAndClose(p.outPath)
+}